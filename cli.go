@@ -0,0 +1,176 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	gridPkg "github.com/joshspicer/nonogram/grid"
+	"github.com/joshspicer/nonogram/parser"
+	"github.com/joshspicer/nonogram/reader"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "clues":
+		cmdClues(os.Args[2:])
+	case "solve":
+		cmdSolve(os.Args[2:])
+	case "verify":
+		cmdVerify(os.Args[2:])
+	case "generate":
+		cmdGenerate(os.Args[2:])
+	case "reduce":
+		cmdReduce(os.Args[2:])
+	case "render":
+		cmdRender(os.Args[2:])
+	case "-h", "--help", "help":
+		printUsage()
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", os.Args[1])
+		printUsage()
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Println(`Squared Away Nonogram Generator (Go)
+
+Usage: nonogram <command> [flags]
+
+Commands:
+  clues     print Phase 1/2 clues for a grid
+  solve     reconstruct a grid from a clue-only source (e.g. .non)
+  verify    report whether a grid's clues are uniquely solvable
+  generate  build a new random puzzle
+  reduce    minimize a grid to its smallest uniquely-solvable form
+  render    render a puzzle to a printable PDF
+
+Every command accepts -i <path> (default stdin), -o <path> (default
+stdout), and -format auto|raw|non|json (default auto).
+
+Set NONOGRAM_TRACE=1 to log line-solver and reducer diagnostics to stderr.`)
+}
+
+// addIOFlags registers the -i, -o, and -format flags shared by every
+// subcommand and returns pointers to their values.
+func addIOFlags(fs *flag.FlagSet) (input, output, format *string) {
+	input = fs.String("i", "", "input path (default stdin)")
+	output = fs.String("o", "", "output path (default stdout)")
+	format = fs.String("format", string(FormatAuto), "input format: auto, raw, non, or json")
+	return input, output, format
+}
+
+// readPuzzle reads and parses a puzzle from path (stdin if empty) in format.
+func readPuzzle(path string, format Format) (Puzzle, error) {
+	data, err := reader.ReadInput(path)
+	if err != nil {
+		return Puzzle{}, err
+	}
+	return parser.ParsePuzzle(data, format)
+}
+
+// openOutput returns a writer for path (stdout if empty) and a close
+// function the caller must defer.
+func openOutput(path string) (io.Writer, func() error, error) {
+	if path == "" || path == "-" {
+		return os.Stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
+
+// cmdClues implements `nonogram clues`: print Phase 1/2 clues for a grid.
+func cmdClues(args []string) {
+	fs := flag.NewFlagSet("clues", flag.ExitOnError)
+	input, output, format := addIOFlags(fs)
+	verbose := fs.Bool("v", false, "also report uniqueness of each phase's clues")
+	fs.Parse(args)
+
+	puzzle, err := readPuzzle(*input, Format(*format))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+		os.Exit(1)
+	}
+
+	w, closeFn, err := openOutput(*output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening output: %v\n", err)
+		os.Exit(1)
+	}
+	defer closeFn()
+
+	if err := writeClues(w, puzzle.Grid, *verbose); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing clues: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// cmdSolve implements `nonogram solve`: parse a puzzle and print its
+// (possibly reconstructed) grid. For clue-only sources like .non, the
+// parser itself runs the line-solver; for raw/JSON sources the grid is
+// already concrete and this just re-prints it.
+func cmdSolve(args []string) {
+	fs := flag.NewFlagSet("solve", flag.ExitOnError)
+	input, output, format := addIOFlags(fs)
+	fs.Parse(args)
+
+	puzzle, err := readPuzzle(*input, Format(*format))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+		os.Exit(1)
+	}
+
+	w, closeFn, err := openOutput(*output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening output: %v\n", err)
+		os.Exit(1)
+	}
+	defer closeFn()
+
+	for _, row := range puzzle.Grid {
+		fmt.Fprintln(w, string(row))
+	}
+}
+
+// cmdVerify implements `nonogram verify`: report whether a grid's Phase 1
+// and Phase 2 clues are uniquely solvable.
+func cmdVerify(args []string) {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	input, output, format := addIOFlags(fs)
+	fs.Parse(args)
+
+	puzzle, err := readPuzzle(*input, Format(*format))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+		os.Exit(1)
+	}
+
+	w, closeFn, err := openOutput(*output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening output: %v\n", err)
+		os.Exit(1)
+	}
+	defer closeFn()
+
+	grid := puzzle.Grid
+	if len(grid) == 0 {
+		fmt.Fprintln(w, "Error: empty grid")
+		os.Exit(1)
+	}
+	width, height := len(grid[0]), len(grid)
+	shadingRowClues, shadingColClues := gridPkg.GenerateShadingClues(grid)
+	erasingRowClues, erasingColClues := gridPkg.GenerateErasingClues(grid)
+
+	fmt.Fprintf(w, "Phase 1: %s\n", verifyPhrase(shadingRowClues, shadingColClues, width, height))
+	fmt.Fprintf(w, "Phase 2: %s\n", verifyPhrase(erasingRowClues, erasingColClues, width, height))
+}
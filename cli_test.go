@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteCluesVerify(t *testing.T) {
+	grid := Grid{
+		{'X', '1', '-'},
+		{'-', '-', '1'},
+		{'1', 'X', '1'},
+	}
+
+	var buf bytes.Buffer
+	if err := writeClues(&buf, grid, true); err != nil {
+		t.Fatalf("writeClues() error = %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "Phase 1 - Shading Clues:") {
+		t.Errorf("writeClues() output missing Phase 1 section:\n%s", out)
+	}
+	if !strings.Contains(out, "\nVerification:\n") || !strings.Contains(out, "Phase 1: unique") {
+		t.Errorf("writeClues() output missing verification lines:\n%s", out)
+	}
+}
+
+func TestWriteCluesEmptyGrid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeClues(&buf, Grid{}, false); err != nil {
+		t.Fatalf("writeClues() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "Error: empty grid") {
+		t.Errorf("writeClues(empty) = %q, want an empty-grid message", buf.String())
+	}
+}
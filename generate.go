@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joshspicer/nonogram/grid"
+	"github.com/joshspicer/nonogram/solve"
+)
+
+// GenerateOptions controls Generate's search for a candidate grid.
+type GenerateOptions struct {
+	// Seed seeds the random source. A zero value uses a time-seeded source.
+	Seed int64
+	// MinRatio and MaxRatio bound the fraction of non-empty cells ('1', '2',
+	// or 'X') allowed per row and column of the initial random fill. Zero
+	// values default to 0.3 and 0.7, mirroring Takuzu's density knobs.
+	MinRatio, MaxRatio float64
+	// Simple requires both phases to be solvable by constraint propagation
+	// alone, with no backtracking.
+	Simple bool
+	// Timeout bounds the total time Generate may spend searching for a
+	// candidate grid and reducing it. A zero value means no limit.
+	Timeout time.Duration
+}
+
+// Generate builds a valid, minimal Squared Away puzzle of the given size: it
+// randomly fills cells respecting opts' density bounds, verifies both phases
+// are uniquely solvable (and, if opts.Simple, trivially so), then reduces the
+// result with ReduceGrid so the final grid is minimal.
+func Generate(width, height int, opts GenerateOptions) (Grid, error) {
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("generate: width and height must be positive")
+	}
+
+	minRatio, maxRatio := opts.MinRatio, opts.MaxRatio
+	if minRatio == 0 && maxRatio == 0 {
+		minRatio, maxRatio = 0.3, 0.7
+	}
+	if minRatio < 0 || maxRatio > 1 || minRatio > maxRatio {
+		return nil, errors.New("generate: min/max ratio must satisfy 0 <= min <= max <= 1")
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	if opts.Seed == 0 {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	var deadline time.Time
+	if opts.Timeout > 0 {
+		deadline = time.Now().Add(opts.Timeout)
+	}
+
+	want := solve.AnyDifficulty
+	if opts.Simple {
+		want = solve.RequireTrivial
+	}
+
+	for {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return nil, errors.New("generate: timed out before finding a valid puzzle")
+		}
+
+		candidate := randomGrid(width, height, minRatio, maxRatio, rng)
+		ok, err := solve.IsUniquelySolvable(candidate, width, height, want, deadline)
+		if err == solve.ErrTimedOut {
+			return nil, errors.New("generate: timed out before finding a valid puzzle")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		reduceTimeout := time.Duration(0)
+		if !deadline.IsZero() {
+			reduceTimeout = time.Until(deadline)
+			if reduceTimeout <= 0 {
+				return nil, errors.New("generate: timed out before reducing a valid puzzle")
+			}
+		}
+		reduced, err := ReduceGrid(candidate, ReduceOptions{
+			Timeout:    reduceTimeout,
+			NonTrivial: false,
+			Rand:       rng,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return reduced, nil
+	}
+}
+
+// cmdGenerate implements `nonogram generate -w W -h H [-seed N]`: it builds a
+// new puzzle with Generate and writes the resulting grid. With -interactive,
+// it instead prompts for dimensions and prints an empty grid to fill in by
+// hand.
+func cmdGenerate(args []string) {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	_, output, _ := addIOFlags(fs)
+	width := fs.Int("w", 10, "puzzle width")
+	height := fs.Int("h", 10, "puzzle height")
+	seed := fs.Int64("seed", 0, "random seed (0 picks a time-based seed)")
+	minRatio := fs.Float64("min", 0, "minimum ratio of filled cells per row/column")
+	maxRatio := fs.Float64("max", 0, "maximum ratio of filled cells per row/column")
+	simple := fs.Bool("simple", false, "require a puzzle solvable without backtracking")
+	timeout := fs.Duration("timeout", 30*time.Second, "maximum time to spend generating")
+	interactive := fs.Bool("interactive", false, "prompt for dimensions and print an empty grid")
+	fs.Parse(args)
+
+	w, closeFn, err := openOutput(*output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening output: %v\n", err)
+		os.Exit(1)
+	}
+	defer closeFn()
+
+	if *interactive {
+		runInteractiveGenerate(w)
+		return
+	}
+
+	grid, err := Generate(*width, *height, GenerateOptions{
+		Seed:     *seed,
+		MinRatio: *minRatio,
+		MaxRatio: *maxRatio,
+		Simple:   *simple,
+		Timeout:  *timeout,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating puzzle: %v\n", err)
+		os.Exit(1)
+	}
+
+	for _, row := range grid {
+		fmt.Fprintln(w, string(row))
+	}
+}
+
+// runInteractiveGenerate prompts on stdin for puzzle dimensions and writes an
+// empty grid to w, ready to be hand-filled and fed back through the other
+// subcommands.
+func runInteractiveGenerate(w io.Writer) {
+	reader := bufio.NewReader(os.Stdin)
+
+	fmt.Print("Enter puzzle width: ")
+	widthStr, err := reader.ReadString('\n')
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading width: %v\n", err)
+		os.Exit(1)
+	}
+	width, err := strconv.Atoi(strings.TrimSpace(widthStr))
+	if err != nil || width <= 0 {
+		fmt.Println("Please enter a valid positive integer for width")
+		os.Exit(1)
+	}
+
+	fmt.Print("Enter puzzle height: ")
+	heightStr, err := reader.ReadString('\n')
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading height: %v\n", err)
+		os.Exit(1)
+	}
+	height, err := strconv.Atoi(strings.TrimSpace(heightStr))
+	if err != nil || height <= 0 {
+		fmt.Println("Please enter a valid positive integer for height")
+		os.Exit(1)
+	}
+
+	g := grid.CreateEmptyGrid(width, height)
+	fmt.Fprintf(w, "\nEmpty %dx%d grid created.\n", width, height)
+	fmt.Fprintln(w, "Grid format:")
+	for _, row := range g {
+		fmt.Fprintln(w, string(row))
+	}
+
+	fmt.Fprintln(w, "\nTo use this grid:")
+	fmt.Fprintln(w, "- Save the grid to a file")
+	fmt.Fprintln(w, "- Edit the file: '-' = empty, '1' = phase 1, '2' = phase 2, 'X' = both phases")
+	fmt.Fprintln(w, "- Run: nonogram clues < your_file.txt")
+}
+
+// randomGrid fills a width x height grid with '1', '2', 'X', and '-',
+// keeping each row's and each column's ratio of non-'-' cells within
+// [minRatio, maxRatio].
+func randomGrid(width, height int, minRatio, maxRatio float64, rng *rand.Rand) Grid {
+	marks := []rune{'1', '2', 'X'}
+
+	const maxAttempts = 50
+	var best Grid
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		grid := make(Grid, height)
+		for i := range grid {
+			grid[i] = make([]rune, width)
+			for j := range grid[i] {
+				grid[i][j] = '-'
+			}
+		}
+
+		for i := 0; i < height; i++ {
+			target := minRatio + rng.Float64()*(maxRatio-minRatio)
+			count := int(target * float64(width))
+			cols := rng.Perm(width)
+			for _, j := range cols[:count] {
+				grid[i][j] = marks[rng.Intn(len(marks))]
+			}
+		}
+
+		best = grid
+		if gridSatisfiesColumnRatio(grid, width, height, minRatio, maxRatio) {
+			return grid
+		}
+	}
+	// Couldn't hit the column ratio exactly within maxAttempts; return the
+	// closest candidate and let the uniqueness check outside decide.
+	return best
+}
+
+// gridSatisfiesColumnRatio reports whether every column's ratio of non-'-'
+// cells falls within [minRatio, maxRatio].
+func gridSatisfiesColumnRatio(grid Grid, width, height int, minRatio, maxRatio float64) bool {
+	for j := 0; j < width; j++ {
+		filled := 0
+		for i := 0; i < height; i++ {
+			if grid[i][j] != '-' {
+				filled++
+			}
+		}
+		ratio := float64(filled) / float64(height)
+		if ratio < minRatio-0.01 || ratio > maxRatio+0.01 {
+			return false
+		}
+	}
+	return true
+}
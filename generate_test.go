@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/joshspicer/nonogram/solve"
+)
+
+func TestGenerateProducesUniquelySolvablePuzzle(t *testing.T) {
+	grid, err := Generate(4, 4, GenerateOptions{Seed: 42})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(grid) != 4 || len(grid[0]) != 4 {
+		t.Fatalf("Generate() grid size = %dx%d, want 4x4", len(grid[0]), len(grid))
+	}
+
+	ok, err := solve.IsUniquelySolvable(grid, 4, 4, solve.AnyDifficulty, time.Time{})
+	if err != nil {
+		t.Fatalf("IsUniquelySolvable() error = %v", err)
+	}
+	if !ok {
+		t.Errorf("Generate() produced a grid that is not uniquely solvable: %v", grid)
+	}
+}
+
+func TestGenerateRejectsNonPositiveDimensions(t *testing.T) {
+	if _, err := Generate(0, 4, GenerateOptions{}); err == nil {
+		t.Errorf("Generate(0, 4) error = nil, want error")
+	}
+}
@@ -0,0 +1,167 @@
+// Package grid holds the nonogram Grid type and the pure functions for
+// building and reading it: parsing the raw character format and deriving
+// Phase 1/2 clues. It has no dependency on the solver or the CLI, so it can
+// be imported by anything that needs to work with a grid's shape without
+// pulling in how grids get solved or rendered.
+package grid
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Grid represents a 2D nonogram grid
+type Grid [][]rune
+
+// ParseGrid parses the input grid string into a 2D Grid
+func ParseGrid(gridStr string) Grid {
+	lines := strings.Split(strings.TrimSpace(gridStr), "\n")
+	grid := make(Grid, len(lines))
+
+	for i, line := range lines {
+		grid[i] = []rune(strings.TrimSpace(line))
+	}
+
+	return grid
+}
+
+// GenerateShadingClues generates the phase 1 shading clues for rows and columns.
+// Cells marked as '1' or 'X' are part of Phase 1 solution.
+func GenerateShadingClues(grid Grid) ([][]int, [][]int) {
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return nil, nil
+	}
+
+	height := len(grid)
+	width := len(grid[0])
+
+	// Row clues
+	rowClues := make([][]int, height)
+	for i, row := range grid {
+		clues := []int{}
+		count := 0
+
+		for _, cell := range row {
+			if cell == '1' || cell == 'X' { // Cells to be shaded in Phase 1
+				count++
+			} else if count > 0 {
+				clues = append(clues, count)
+				count = 0
+			}
+		}
+		if count > 0 {
+			clues = append(clues, count)
+		}
+		if len(clues) == 0 {
+			clues = append(clues, 0)
+		}
+		rowClues[i] = clues
+	}
+
+	// Column clues
+	colClues := make([][]int, width)
+	for j := 0; j < width; j++ {
+		clues := []int{}
+		count := 0
+
+		for i := 0; i < height; i++ {
+			cell := grid[i][j]
+			if cell == '1' || cell == 'X' { // Cells to be shaded in Phase 1
+				count++
+			} else if count > 0 {
+				clues = append(clues, count)
+				count = 0
+			}
+		}
+		if count > 0 {
+			clues = append(clues, count)
+		}
+		if len(clues) == 0 {
+			clues = append(clues, 0)
+		}
+		colClues[j] = clues
+	}
+
+	return rowClues, colClues
+}
+
+// GenerateErasingClues generates the phase 2 erasing clues for rows and columns.
+// Cells marked as '2' or 'X' are to be erased in Phase 2.
+func GenerateErasingClues(grid Grid) ([][]int, [][]int) {
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return nil, nil
+	}
+
+	height := len(grid)
+	width := len(grid[0])
+
+	// Row clues
+	rowClues := make([][]int, height)
+	for i, row := range grid {
+		clues := []int{}
+		count := 0
+
+		for _, cell := range row {
+			if cell == '2' || cell == 'X' { // Cells to be erased in Phase 2
+				count++
+			} else if count > 0 {
+				clues = append(clues, count)
+				count = 0
+			}
+		}
+		if count > 0 {
+			clues = append(clues, count)
+		}
+		if len(clues) == 0 {
+			clues = append(clues, 0)
+		}
+		rowClues[i] = clues
+	}
+
+	// Column clues
+	colClues := make([][]int, width)
+	for j := 0; j < width; j++ {
+		clues := []int{}
+		count := 0
+
+		for i := 0; i < height; i++ {
+			cell := grid[i][j]
+			if cell == '2' || cell == 'X' { // Cells to be erased in Phase 2
+				count++
+			} else if count > 0 {
+				clues = append(clues, count)
+				count = 0
+			}
+		}
+		if count > 0 {
+			clues = append(clues, count)
+		}
+		if len(clues) == 0 {
+			clues = append(clues, 0)
+		}
+		colClues[j] = clues
+	}
+
+	return rowClues, colClues
+}
+
+// FormatClues formats a slice of integers as a space-separated string
+func FormatClues(clues []int) string {
+	strs := make([]string, len(clues))
+	for i, clue := range clues {
+		strs[i] = strconv.Itoa(clue)
+	}
+	return strings.Join(strs, " ")
+}
+
+// CreateEmptyGrid creates an empty grid with specified dimensions
+func CreateEmptyGrid(width, height int) Grid {
+	grid := make(Grid, height)
+	for i := range grid {
+		grid[i] = make([]rune, width)
+		for j := range grid[i] {
+			grid[i][j] = '-'
+		}
+	}
+	return grid
+}
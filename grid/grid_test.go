@@ -1,4 +1,4 @@
-package main
+package grid
 
 import (
 	"reflect"
@@ -12,10 +12,10 @@ func TestParseGrid(t *testing.T) {
 		{'-', '2', '-'},
 		{'1', 'X', '2'},
 	}
-	
-	result := parseGrid(input)
+
+	result := ParseGrid(input)
 	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("parseGrid() = %v, want %v", result, expected)
+		t.Errorf("ParseGrid() = %v, want %v", result, expected)
 	}
 }
 
@@ -25,7 +25,7 @@ func TestGenerateShadingClues(t *testing.T) {
 		{'-', '-', '1'},
 		{'1', 'X', '1'},
 	}
-	
+
 	expectedRowClues := [][]int{
 		{2},    // X1 = 2 consecutive
 		{1},    // single 1
@@ -33,17 +33,17 @@ func TestGenerateShadingClues(t *testing.T) {
 	}
 	expectedColClues := [][]int{
 		{1, 1}, // X in row 1, 1 in row 3
-		{1, 1}, // 1 in row 1, X in row 3  
+		{1, 1}, // 1 in row 1, X in row 3
 		{2},    // 1 in row 2, 1 in row 3 = 2 consecutive
 	}
-	
-	rowClues, colClues := generateShadingClues(grid)
-	
+
+	rowClues, colClues := GenerateShadingClues(grid)
+
 	if !reflect.DeepEqual(rowClues, expectedRowClues) {
-		t.Errorf("generateShadingClues() rowClues = %v, want %v", rowClues, expectedRowClues)
+		t.Errorf("GenerateShadingClues() rowClues = %v, want %v", rowClues, expectedRowClues)
 	}
 	if !reflect.DeepEqual(colClues, expectedColClues) {
-		t.Errorf("generateShadingClues() colClues = %v, want %v", colClues, expectedColClues)
+		t.Errorf("GenerateShadingClues() colClues = %v, want %v", colClues, expectedColClues)
 	}
 }
 
@@ -53,9 +53,9 @@ func TestGenerateErasingClues(t *testing.T) {
 		{'-', '-', '2'},
 		{'2', 'X', '2'},
 	}
-	
+
 	expectedRowClues := [][]int{
-		{2},    // X2 = 2 consecutive  
+		{2},    // X2 = 2 consecutive
 		{1},    // single 2
 		{3},    // 2X2 = 3 consecutive (2 and X are both phase 2)
 	}
@@ -64,14 +64,14 @@ func TestGenerateErasingClues(t *testing.T) {
 		{1, 1}, // 2 in row 1, X in row 3
 		{2},    // 2 in row 2, 2 in row 3 = 2 consecutive
 	}
-	
-	rowClues, colClues := generateErasingClues(grid)
-	
+
+	rowClues, colClues := GenerateErasingClues(grid)
+
 	if !reflect.DeepEqual(rowClues, expectedRowClues) {
-		t.Errorf("generateErasingClues() rowClues = %v, want %v", rowClues, expectedRowClues)
+		t.Errorf("GenerateErasingClues() rowClues = %v, want %v", rowClues, expectedRowClues)
 	}
 	if !reflect.DeepEqual(colClues, expectedColClues) {
-		t.Errorf("generateErasingClues() colClues = %v, want %v", colClues, expectedColClues)
+		t.Errorf("GenerateErasingClues() colClues = %v, want %v", colClues, expectedColClues)
 	}
 }
 
@@ -81,19 +81,19 @@ func TestCreateEmptyGrid(t *testing.T) {
 		{'-', '-', '-'},
 		{'-', '-', '-'},
 	}
-	
-	result := createEmptyGrid(width, height)
+
+	result := CreateEmptyGrid(width, height)
 	if !reflect.DeepEqual(result, expected) {
-		t.Errorf("createEmptyGrid() = %v, want %v", result, expected)
+		t.Errorf("CreateEmptyGrid() = %v, want %v", result, expected)
 	}
 }
 
 func TestFormatClues(t *testing.T) {
 	clues := []int{1, 2, 3}
 	expected := "1 2 3"
-	
-	result := formatClues(clues)
+
+	result := FormatClues(clues)
 	if result != expected {
-		t.Errorf("formatClues() = %v, want %v", result, expected)
+		t.Errorf("FormatClues() = %v, want %v", result, expected)
 	}
-}
\ No newline at end of file
+}
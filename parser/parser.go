@@ -0,0 +1,221 @@
+// Package parser decodes puzzle bytes in any of the formats this repo
+// understands (the native raw grid, the widely-used .non clue format, and
+// this repo's JSON schema) into a Puzzle. It depends on the solve package to
+// reconstruct a concrete grid from clue-only sources like .non, and on the
+// grid package for the Grid type itself, so it can be imported independently
+// of the CLI to interop with the broader nonogram ecosystem.
+package parser
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/joshspicer/nonogram/grid"
+	"github.com/joshspicer/nonogram/solve"
+)
+
+// Format identifies how puzzle bytes should be decoded.
+type Format string
+
+const (
+	// FormatAuto sniffs the input and picks one of the formats below.
+	FormatAuto Format = "auto"
+	// FormatRaw is the repo's native '-'/'1'/'2'/'X' character grid.
+	FormatRaw Format = "raw"
+	// FormatNon is the widely-used .non clue format (width/height/rows/columns).
+	FormatNon Format = "non"
+	// FormatJSON is this repo's two-phase grid-plus-metadata JSON schema.
+	FormatJSON Format = "json"
+)
+
+// Puzzle is a Grid together with optional metadata, as produced by any of
+// the supported parsers.
+type Puzzle struct {
+	Grid   grid.Grid
+	Title  string
+	Author string
+}
+
+// ParsePuzzle decodes data using format, or sniffs the format when format is
+// FormatAuto (or empty).
+func ParsePuzzle(data []byte, format Format) (Puzzle, error) {
+	if format == "" || format == FormatAuto {
+		format = detectFormat(data)
+	}
+
+	switch format {
+	case FormatRaw:
+		return Puzzle{Grid: grid.ParseGrid(string(data))}, nil
+	case FormatNon:
+		return parseNon(data)
+	case FormatJSON:
+		return parseJSONPuzzle(data)
+	default:
+		return Puzzle{}, errors.New("parsePuzzle: unknown format " + string(format))
+	}
+}
+
+// detectFormat sniffs the input's format by looking for unambiguous markers:
+// a leading '{' means JSON, and "width"/"height" header lines mean .non.
+// Anything else is treated as the raw character grid.
+func detectFormat(data []byte) Format {
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "{") {
+		return FormatJSON
+	}
+	scanner := bufio.NewScanner(strings.NewReader(trimmed))
+	for scanner.Scan() {
+		line := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if strings.HasPrefix(line, "width") || strings.HasPrefix(line, "height") {
+			return FormatNon
+		}
+	}
+	return FormatRaw
+}
+
+// parseNon decodes the .non format: "width"/"height" scalars, "catalogue"/
+// "title"/"by" metadata, and "rows"/"columns" sections each holding one
+// comma-separated clue list per line. The clues are reconstructed into a
+// concrete grid via solve.Solve, marking filled cells '1' (this format has no
+// notion of a second, erasing phase).
+func parseNon(data []byte) (Puzzle, error) {
+	var width, height int
+	var title, author string
+	var rowClues, colClues [][]int
+
+	section := ""
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "|") {
+			continue
+		}
+
+		lower := strings.ToLower(line)
+		switch {
+		case strings.HasPrefix(lower, "width"):
+			section = ""
+			n, err := strconv.Atoi(strings.TrimSpace(line[len("width"):]))
+			if err != nil {
+				return Puzzle{}, errors.New("parseNon: invalid width: " + line)
+			}
+			width = n
+			continue
+		case strings.HasPrefix(lower, "height"):
+			section = ""
+			n, err := strconv.Atoi(strings.TrimSpace(line[len("height"):]))
+			if err != nil {
+				return Puzzle{}, errors.New("parseNon: invalid height: " + line)
+			}
+			height = n
+			continue
+		case strings.HasPrefix(lower, "title"):
+			title = unquoteNon(strings.TrimSpace(line[len("title"):]))
+			continue
+		case strings.HasPrefix(lower, "by"):
+			author = unquoteNon(strings.TrimSpace(line[len("by"):]))
+			continue
+		case strings.HasPrefix(lower, "catalogue"):
+			continue
+		case lower == "rows":
+			section = "rows"
+			continue
+		case lower == "columns":
+			section = "columns"
+			continue
+		}
+
+		clue, err := parseNonClueLine(line)
+		if err != nil {
+			return Puzzle{}, err
+		}
+		switch section {
+		case "rows":
+			rowClues = append(rowClues, clue)
+		case "columns":
+			colClues = append(colClues, clue)
+		default:
+			return Puzzle{}, errors.New("parseNon: clue line outside rows/columns section: " + line)
+		}
+	}
+
+	if width == 0 || height == 0 {
+		return Puzzle{}, errors.New("parseNon: missing width/height")
+	}
+	if len(rowClues) != height || len(colClues) != width {
+		return Puzzle{}, errors.New("parseNon: clue count does not match width/height")
+	}
+
+	solved, ok, err := solve.Solve(rowClues, colClues, width, height)
+	if err != nil {
+		return Puzzle{}, err
+	}
+	if !ok {
+		return Puzzle{}, errors.New("parseNon: clues are not solvable")
+	}
+
+	return Puzzle{Grid: solved, Title: title, Author: author}, nil
+}
+
+// parseNonClueLine parses a comma-separated clue list, treating an empty
+// line as the "0" (empty line) clue.
+func parseNonClueLine(line string) ([]int, error) {
+	parts := strings.Split(line, ",")
+	clue := make([]int, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return nil, errors.New("parseNon: invalid clue value: " + p)
+		}
+		clue = append(clue, n)
+	}
+	if len(clue) == 0 {
+		clue = []int{0}
+	}
+	return clue, nil
+}
+
+// unquoteNon strips a pair of surrounding double quotes, if present.
+func unquoteNon(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// jsonPuzzle is the on-disk JSON schema: one string per grid row, using the
+// same '-'/'1'/'2'/'X' alphabet as the raw format, plus optional metadata.
+type jsonPuzzle struct {
+	Title  string   `json:"title,omitempty"`
+	Author string   `json:"author,omitempty"`
+	Grid   []string `json:"grid"`
+}
+
+func parseJSONPuzzle(data []byte) (Puzzle, error) {
+	var decoded jsonPuzzle
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return Puzzle{}, err
+	}
+	if len(decoded.Grid) == 0 {
+		return Puzzle{}, errors.New("parseJSONPuzzle: grid is empty")
+	}
+
+	g := make(grid.Grid, len(decoded.Grid))
+	width := len([]rune(decoded.Grid[0]))
+	for i, row := range decoded.Grid {
+		runes := []rune(row)
+		if len(runes) != width {
+			return Puzzle{}, errors.New("parseJSONPuzzle: grid rows have inconsistent width")
+		}
+		g[i] = runes
+	}
+
+	return Puzzle{Grid: g, Title: decoded.Title, Author: decoded.Author}, nil
+}
@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/joshspicer/nonogram/grid"
+)
+
+func TestParsePuzzleRaw(t *testing.T) {
+	puzzle, err := ParsePuzzle([]byte("X1X\n-2-\n1X2"), FormatRaw)
+	if err != nil {
+		t.Fatalf("ParsePuzzle() error = %v", err)
+	}
+	expected := grid.Grid{
+		{'X', '1', 'X'},
+		{'-', '2', '-'},
+		{'1', 'X', '2'},
+	}
+	if !reflect.DeepEqual(puzzle.Grid, expected) {
+		t.Errorf("ParsePuzzle() grid = %v, want %v", puzzle.Grid, expected)
+	}
+}
+
+func TestParsePuzzleJSON(t *testing.T) {
+	input := []byte(`{"title":"Sample","author":"Jo","grid":["X1-","--2","1X2"]}`)
+	puzzle, err := ParsePuzzle(input, FormatAuto)
+	if err != nil {
+		t.Fatalf("ParsePuzzle() error = %v", err)
+	}
+	if puzzle.Title != "Sample" || puzzle.Author != "Jo" {
+		t.Errorf("ParsePuzzle() metadata = %q/%q, want Sample/Jo", puzzle.Title, puzzle.Author)
+	}
+	expected := grid.Grid{
+		{'X', '1', '-'},
+		{'-', '-', '2'},
+		{'1', 'X', '2'},
+	}
+	if !reflect.DeepEqual(puzzle.Grid, expected) {
+		t.Errorf("ParsePuzzle() grid = %v, want %v", puzzle.Grid, expected)
+	}
+}
+
+func TestParsePuzzleNon(t *testing.T) {
+	input := []byte(`title "Tiny"
+by Jo
+width 2
+height 2
+rows
+1
+1
+columns
+1
+1
+`)
+	puzzle, err := ParsePuzzle(input, FormatAuto)
+	if err != nil {
+		t.Fatalf("ParsePuzzle() error = %v", err)
+	}
+	if puzzle.Title != "Tiny" || puzzle.Author != "Jo" {
+		t.Errorf("ParsePuzzle() metadata = %q/%q, want Tiny/Jo", puzzle.Title, puzzle.Author)
+	}
+	if len(puzzle.Grid) != 2 || len(puzzle.Grid[0]) != 2 {
+		t.Fatalf("ParsePuzzle() grid size = %dx%d, want 2x2", len(puzzle.Grid[0]), len(puzzle.Grid))
+	}
+
+	rowClues, colClues := grid.GenerateShadingClues(puzzle.Grid)
+	expected := [][]int{{1}, {1}}
+	if !reflect.DeepEqual(rowClues, expected) || !reflect.DeepEqual(colClues, expected) {
+		t.Errorf("ParsePuzzle() reconstructed clues = %v/%v, want %v/%v", rowClues, colClues, expected, expected)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+		want Format
+	}{
+		{"raw", "X1X\n-2-", FormatRaw},
+		{"json", `{"grid":["X"]}`, FormatJSON},
+		{"non", "width 5\nheight 5\n", FormatNon},
+	}
+	for _, c := range cases {
+		if got := detectFormat([]byte(c.data)); got != c.want {
+			t.Errorf("detectFormat(%s) = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Package reader pulls puzzle bytes from a file, stdin, or any other source
+// named by a path, independent of how those bytes get parsed. This keeps the
+// "where do the bytes come from" concern importable and testable apart from
+// the format-specific parsing in package parser.
+package reader
+
+import (
+	"io"
+	"os"
+)
+
+// ReadInput returns the bytes for a puzzle source: path == "" or "-" reads
+// stdin, any other value is treated as a file path.
+func ReadInput(path string) ([]byte, error) {
+	if path == "" || path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
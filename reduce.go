@@ -0,0 +1,155 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/joshspicer/nonogram/solve"
+	"github.com/joshspicer/nonogram/trace"
+)
+
+// ReduceOptions controls how aggressively ReduceGrid trims a grid.
+type ReduceOptions struct {
+	// Timeout bounds how long reduction may run. A zero value means no limit.
+	Timeout time.Duration
+	// NonTrivial, when true, rejects a reduction if the resulting puzzle can
+	// be solved by constraint propagation alone, forcing the result to
+	// require backtracking in at least one phase.
+	NonTrivial bool
+	// Rand supplies the order in which candidate cells are tried. If nil,
+	// a time-seeded source is used.
+	Rand *rand.Rand
+}
+
+// ReduceGrid repeatedly clears a random still-marked cell ('1', '2', or 'X')
+// from grid and keeps the change only if both the Phase 1 shading puzzle and
+// the Phase 2 erasing puzzle remain uniquely solvable; otherwise the mark is
+// restored. It continues until no further removal succeeds or opts.Timeout
+// elapses, and returns the minimal grid found. grid itself must already
+// satisfy opts' difficulty requirement, and the returned grid is guaranteed
+// to satisfy it too; ReduceGrid returns an error rather than silently handing
+// back a grid that doesn't.
+func ReduceGrid(grid Grid, opts ReduceOptions) (Grid, error) {
+	if len(grid) == 0 || len(grid[0]) == 0 {
+		return nil, errors.New("reduceGrid: empty grid")
+	}
+
+	height := len(grid)
+	width := len(grid[0])
+	result := make(Grid, height)
+	for i, row := range grid {
+		result[i] = append([]rune(nil), row...)
+	}
+
+	rng := opts.Rand
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	var deadline time.Time
+	if opts.Timeout > 0 {
+		deadline = time.Now().Add(opts.Timeout)
+	}
+
+	want := solve.AnyDifficulty
+	if opts.NonTrivial {
+		want = solve.RequireNonTrivial
+	}
+
+	ok, err := solve.IsUniquelySolvable(result, width, height, want, deadline)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("reduceGrid: input grid does not satisfy the requested difficulty")
+	}
+
+	type cell struct{ i, j int }
+	var candidates []cell
+	for i := 0; i < height; i++ {
+		for j := 0; j < width; j++ {
+			if result[i][j] != '-' {
+				candidates = append(candidates, cell{i, j})
+			}
+		}
+	}
+
+	for len(candidates) > 0 {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			break
+		}
+
+		idx := rng.Intn(len(candidates))
+		c := candidates[idx]
+		original := result[c.i][c.j]
+		result[c.i][c.j] = '-'
+
+		ok, err := solve.IsUniquelySolvable(result, width, height, want, deadline)
+		if err == solve.ErrTimedOut {
+			result[c.i][c.j] = original
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			trace.Printf("reduce: cleared (%d,%d) was %q, %d cells left to try", c.i, c.j, original, len(candidates)-1)
+			candidates[idx] = candidates[len(candidates)-1]
+			candidates = candidates[:len(candidates)-1]
+		} else {
+			trace.Printf("reduce: restored (%d,%d)=%q, removal broke uniqueness", c.i, c.j, original)
+			result[c.i][c.j] = original
+			candidates[idx] = candidates[len(candidates)-1]
+			candidates = candidates[:len(candidates)-1]
+		}
+	}
+
+	ok, err = solve.IsUniquelySolvable(result, width, height, want, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("reduceGrid: reduction produced a grid that does not satisfy the requested difficulty")
+	}
+
+	return result, nil
+}
+
+// cmdReduce implements `nonogram reduce`: it reads a grid, minimizes it with
+// ReduceGrid, and writes the result.
+func cmdReduce(args []string) {
+	fs := flag.NewFlagSet("reduce", flag.ExitOnError)
+	input, output, format := addIOFlags(fs)
+	timeout := fs.Duration("timeout", 30*time.Second, "maximum time to spend reducing")
+	nonTrivial := fs.Bool("non-trivial", false, "reject reductions solvable by propagation alone")
+	fs.Parse(args)
+
+	puzzle, err := readPuzzle(*input, Format(*format))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+		os.Exit(1)
+	}
+
+	reduced, err := ReduceGrid(puzzle.Grid, ReduceOptions{
+		Timeout:    *timeout,
+		NonTrivial: *nonTrivial,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reducing grid: %v\n", err)
+		os.Exit(1)
+	}
+
+	w, closeFn, err := openOutput(*output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening output: %v\n", err)
+		os.Exit(1)
+	}
+	defer closeFn()
+	for _, row := range reduced {
+		fmt.Fprintln(w, string(row))
+	}
+}
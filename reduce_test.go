@@ -0,0 +1,63 @@
+package main
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/joshspicer/nonogram/solve"
+)
+
+func TestReduceGridStaysUniquelySolvable(t *testing.T) {
+	grid := Grid{
+		{'-', '1', '-'},
+		{'1', '1', '1'},
+		{'-', '1', '-'},
+	}
+
+	reduced, err := ReduceGrid(grid, ReduceOptions{Rand: rand.New(rand.NewSource(1))})
+	if err != nil {
+		t.Fatalf("ReduceGrid() error = %v", err)
+	}
+
+	ok, err := solve.IsUniquelySolvable(reduced, 3, 3, solve.AnyDifficulty, time.Time{})
+	if err != nil {
+		t.Fatalf("IsUniquelySolvable() error = %v", err)
+	}
+	if !ok {
+		t.Errorf("ReduceGrid() produced a grid that is not uniquely solvable: %v", reduced)
+	}
+
+	removed := 0
+	for i := range grid {
+		for j := range grid[i] {
+			if reduced[i][j] == '-' && grid[i][j] != '-' {
+				removed++
+			}
+		}
+	}
+	if removed == 0 {
+		t.Errorf("ReduceGrid() removed no marks, want at least one")
+	}
+}
+
+func TestReduceGridEmptyInput(t *testing.T) {
+	if _, err := ReduceGrid(Grid{}, ReduceOptions{}); err == nil {
+		t.Errorf("ReduceGrid(empty) error = nil, want error")
+	}
+}
+
+func TestReduceGridRejectsAlreadyAmbiguousInput(t *testing.T) {
+	// This grid's Phase 2 (erasing) clues admit more than one solution, so it
+	// is not uniquely solvable to begin with; ReduceGrid must refuse to hand
+	// it back as if it were a valid minimized puzzle.
+	grid := Grid{
+		{'X', '1', '-'},
+		{'-', '-', '1'},
+		{'1', 'X', '1'},
+	}
+
+	if _, err := ReduceGrid(grid, ReduceOptions{NonTrivial: true, Rand: rand.New(rand.NewSource(1))}); err == nil {
+		t.Errorf("ReduceGrid(ambiguous input) error = nil, want error")
+	}
+}
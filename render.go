@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/joshspicer/nonogram/render"
+)
+
+// cmdRender implements `nonogram render -o sheet.pdf`: it reads a puzzle and
+// writes a printable PDF worksheet.
+func cmdRender(args []string) {
+	fs := flag.NewFlagSet("render", flag.ExitOnError)
+	input, output, format := addIOFlags(fs)
+	cellSize := fs.Float64("cell-size", 0, "grid cell size in millimeters (0 picks the default)")
+	fs.Parse(args)
+
+	if *output == "" {
+		fmt.Fprintln(os.Stderr, "Error: render requires -o <file.pdf>")
+		os.Exit(1)
+	}
+
+	puzzle, err := readPuzzle(*input, Format(*format))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
+		os.Exit(1)
+	}
+
+	f, err := os.Create(*output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening output: %v\n", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	if err := render.PDF(puzzle, f, render.Options{CellSize: *cellSize}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error rendering PDF: %v\n", err)
+		os.Exit(1)
+	}
+}
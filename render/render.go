@@ -0,0 +1,148 @@
+// Package render writes a printable PDF worksheet for a puzzle: a Phase 1
+// clue sheet, a Phase 2 clue sheet, and a solution page. It depends on grid
+// and parser (for the Puzzle type), so it can be imported independently of
+// the CLI, the same as the reader and parser packages it sits alongside.
+package render
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jung-kurt/gofpdf"
+
+	"github.com/joshspicer/nonogram/grid"
+	"github.com/joshspicer/nonogram/parser"
+)
+
+// Options controls RenderPDF's layout.
+type Options struct {
+	// CellSize is the side length of one grid cell, in millimeters. A zero
+	// value defaults to 8mm.
+	CellSize float64
+}
+
+const (
+	pageWidthMM  = 210.0 // A4 portrait
+	pageMarginMM = 15.0
+)
+
+// PDF writes a printable Squared Away worksheet for puzzle to w: a Phase 1
+// (shading) clue sheet, a Phase 2 (erasing) clue sheet, and a solution page
+// showing the completed grid with '1'/'2'/'X' cells styled distinctly.
+func PDF(puzzle parser.Puzzle, w io.Writer, opts Options) error {
+	g := puzzle.Grid
+	if len(g) == 0 || len(g[0]) == 0 {
+		return fmt.Errorf("render: empty grid")
+	}
+
+	cellSize := opts.CellSize
+	if cellSize == 0 {
+		cellSize = 8
+	}
+
+	shadingRowClues, shadingColClues := grid.GenerateShadingClues(g)
+	erasingRowClues, erasingColClues := grid.GenerateErasingClues(g)
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetAutoPageBreak(false, 0)
+
+	title := puzzle.Title
+	if title == "" {
+		title = "Squared Away"
+	}
+
+	addCluePage(pdf, title+" - Phase 1 (Shading)", shadingRowClues, shadingColClues, len(g[0]), len(g), cellSize)
+	addCluePage(pdf, title+" - Phase 2 (Erasing)", erasingRowClues, erasingColClues, len(g[0]), len(g), cellSize)
+	addSolutionPage(pdf, title+" - Solution", g, cellSize)
+
+	return pdf.Output(w)
+}
+
+// addCluePage renders one phase's clues around an otherwise empty grid.
+func addCluePage(pdf *gofpdf.Fpdf, title string, rowClues, colClues [][]int, width, height int, cellSize float64) {
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 10, title, "", 1, "C", false, 0, "")
+
+	originX := pageMarginMM + maxClueWidth(rowClues)*cellSize
+	originY := pageMarginMM + maxClueHeight(colClues)*cellSize
+
+	pdf.SetFont("Helvetica", "", 9)
+	for i, clues := range rowClues {
+		label := grid.FormatClues(clues)
+		y := originY + float64(i)*cellSize
+		pdf.SetXY(pageMarginMM, y)
+		pdf.CellFormat(originX-pageMarginMM-1, cellSize, label, "", 0, "R", false, 0, "")
+	}
+	for j, clues := range colClues {
+		label := grid.FormatClues(clues)
+		x := originX + float64(j)*cellSize
+		pdf.SetXY(x, pageMarginMM)
+		pdf.MultiCell(cellSize, 4, label, "", "C", false)
+	}
+
+	pdf.SetLineWidth(0.2)
+	for i := 0; i <= height; i++ {
+		y := originY + float64(i)*cellSize
+		pdf.Line(originX, y, originX+float64(width)*cellSize, y)
+	}
+	for j := 0; j <= width; j++ {
+		x := originX + float64(j)*cellSize
+		pdf.Line(x, originY, x, originY+float64(height)*cellSize)
+	}
+}
+
+// addSolutionPage renders the completed grid, filling each cell according to
+// its mark so the two phases are visually distinct.
+func addSolutionPage(pdf *gofpdf.Fpdf, title string, g grid.Grid, cellSize float64) {
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 10, title, "", 1, "C", false, 0, "")
+
+	originX := pageMarginMM
+	originY := pageMarginMM + 10
+
+	for i, row := range g {
+		for j, cell := range row {
+			x := originX + float64(j)*cellSize
+			y := originY + float64(i)*cellSize
+			switch cell {
+			case '1':
+				pdf.SetFillColor(60, 60, 60)
+				pdf.Rect(x, y, cellSize, cellSize, "F")
+			case '2':
+				pdf.SetFillColor(200, 200, 255)
+				pdf.Rect(x, y, cellSize, cellSize, "F")
+			case 'X':
+				pdf.SetFillColor(60, 60, 120)
+				pdf.Rect(x, y, cellSize, cellSize, "F")
+			}
+			pdf.Rect(x, y, cellSize, cellSize, "D")
+		}
+	}
+}
+
+// maxClueWidth estimates, in cell-widths, the widest row-clue label so the
+// grid can be offset to leave room for it.
+func maxClueWidth(rowClues [][]int) float64 {
+	widest := 1.0
+	for _, clues := range rowClues {
+		n := float64(len(grid.FormatClues(clues))) / 4
+		if n > widest {
+			widest = n
+		}
+	}
+	return widest
+}
+
+// maxClueHeight estimates, in cell-heights, the tallest column-clue label.
+func maxClueHeight(colClues [][]int) float64 {
+	tallest := 1.0
+	for _, clues := range colClues {
+		n := float64(len(clues))
+		if n > tallest {
+			tallest = n
+		}
+	}
+	return tallest
+}
@@ -0,0 +1,42 @@
+package render
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/joshspicer/nonogram/grid"
+	"github.com/joshspicer/nonogram/parser"
+)
+
+func TestPDFProducesWellFormedOutput(t *testing.T) {
+	puzzle := parser.Puzzle{
+		Grid: grid.Grid{
+			{'X', '1', '-'},
+			{'-', '-', '1'},
+			{'1', 'X', '1'},
+		},
+		Title: "Smoke Test",
+	}
+
+	var buf bytes.Buffer
+	if err := PDF(puzzle, &buf, Options{}); err != nil {
+		t.Fatalf("PDF() error = %v", err)
+	}
+
+	if buf.Len() == 0 {
+		t.Fatal("PDF() wrote no bytes")
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF")) {
+		t.Errorf("PDF() output does not start with the %%PDF magic header: %q", buf.Bytes()[:min(16, buf.Len())])
+	}
+	if !bytes.HasSuffix(bytes.TrimRight(buf.Bytes(), "\n"), []byte("%%EOF")) {
+		t.Errorf("PDF() output does not end with %%%%EOF")
+	}
+}
+
+func TestPDFRejectsEmptyGrid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := PDF(parser.Puzzle{}, &buf, Options{}); err == nil {
+		t.Error("PDF(empty grid) error = nil, want error")
+	}
+}
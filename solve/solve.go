@@ -0,0 +1,482 @@
+// Package solve implements the nonogram line-solver: reconstructing a grid
+// from row/column clues, counting solutions, and judging a puzzle's
+// difficulty. It depends only on the grid package, so it can be reused by
+// anything that needs to solve or verify clues without pulling in the CLI.
+package solve
+
+import (
+	"errors"
+	"time"
+
+	"github.com/joshspicer/nonogram/grid"
+	"github.com/joshspicer/nonogram/trace"
+)
+
+// errTimedOut is returned by solveGrid (and its callers) when deadline
+// elapses mid-search, as distinct from "no solution exists".
+var errTimedOut = errors.New("solve: timed out")
+
+// ErrTimedOut is the sentinel callers can compare against to distinguish a
+// deadline expiring from any other error.
+var ErrTimedOut = errTimedOut
+
+// cellState represents what is known about a single cell while solving.
+type cellState int
+
+const (
+	unknown cellState = iota
+	filled
+	empty
+)
+
+// lineCan reports, for every cell in a line of the given length, whether that
+// cell can be filled and whether it can be empty under at least one valid
+// placement of clues that is consistent with known. It also reports whether
+// any valid placement exists at all.
+//
+// It works by computing f[i][j] = true if clues[i:] can be placed starting at
+// column j (inclusive) without violating known, then walking every block
+// start that f permits and OR-ing the resulting fill pattern into canFill /
+// canEmpty.
+func lineCan(clues []int, known []cellState) (canFill, canEmpty []bool, ok bool) {
+	n := len(known)
+	canFill = make([]bool, n)
+	canEmpty = make([]bool, n)
+
+	// Normalize a "no clue" line (e.g. {0}) to no blocks at all.
+	blocks := clues
+	if len(blocks) == 1 && blocks[0] == 0 {
+		blocks = nil
+	}
+
+	// f[i][j] == true means clues[i:] can start at or after column j and fit.
+	f := make([][]bool, len(blocks)+1)
+	for i := range f {
+		f[i] = make([]bool, n+1)
+	}
+	// With no blocks left to place, every remaining cell must be empty.
+	f[len(blocks)][n] = true
+	for j := n - 1; j >= 0; j-- {
+		f[len(blocks)][j] = f[len(blocks)][j+1] && known[j] != filled
+	}
+	for i := len(blocks) - 1; i >= 0; i-- {
+		for j := n; j >= 0; j-- {
+			// Leave column j empty (no block starts here) and move on; only
+			// valid if j isn't forced filled.
+			if j < n && known[j] != filled && f[i][j+1] {
+				f[i][j] = true
+				continue
+			}
+			length := blocks[i]
+			end := j + length
+			if end > n {
+				continue
+			}
+			// cells [j, end) must be fillable
+			fits := true
+			for k := j; k < end; k++ {
+				if known[k] == empty {
+					fits = false
+					break
+				}
+			}
+			// the cell right after the block (if any) must be allowed empty
+			if fits && end < n && known[end] == filled {
+				fits = false
+			}
+			// remainder of the line must accommodate the rest of the blocks
+			rest := i + 1
+			if fits {
+				restStart := end + 1
+				if restStart > n {
+					restStart = n
+				}
+				if !f[rest][restStart] {
+					fits = false
+				}
+			}
+			if fits {
+				f[i][j] = true
+			}
+		}
+	}
+	if !f[0][0] {
+		return canFill, canEmpty, false
+	}
+
+	// Enumerate every valid placement reachable from f and accumulate the
+	// union of filled/empty cells it produces.
+	var walk func(i, j int, fill []bool)
+	walk = func(i, j int, fill []bool) {
+		if i == len(blocks) {
+			if !f[i][j] {
+				return
+			}
+			pattern := make([]bool, n)
+			copy(pattern, fill)
+			for k := 0; k < n; k++ {
+				if pattern[k] {
+					canFill[k] = true
+				} else {
+					canEmpty[k] = true
+				}
+			}
+			return
+		}
+		if j < n && known[j] != filled && f[i][j+1] {
+			walk(i, j+1, fill)
+		}
+		length := blocks[i]
+		end := j + length
+		if end > n {
+			return
+		}
+		for k := j; k < end; k++ {
+			if known[k] == empty {
+				return
+			}
+		}
+		if end < n && known[end] == filled {
+			return
+		}
+		restStart := end + 1
+		if restStart > n {
+			restStart = n
+		}
+		if !f[i+1][restStart] {
+			return
+		}
+		for k := j; k < end; k++ {
+			fill[k] = true
+		}
+		walk(i+1, restStart, fill)
+		for k := j; k < end; k++ {
+			fill[k] = false
+		}
+	}
+	walk(0, 0, make([]bool, n))
+
+	return canFill, canEmpty, true
+}
+
+// solveGrid runs constraint propagation (line-solving by DP, as in lineCan)
+// until it reaches a fixed point, branching on the lowest-entropy undecided
+// cell when propagation stalls. limit caps the number of distinct solutions
+// collected; a limit of 0 means unlimited. deadline, if non-zero, is checked
+// throughout the branch/propagate recursion (not just between calls to
+// solveGrid) so a single pathological grid can't run past it; solveGrid
+// returns errTimedOut if deadline elapses before the search completes.
+func solveGrid(rowClues, colClues [][]int, width, height, limit int, deadline time.Time) ([]grid.Grid, error) {
+	state := make([][]cellState, height)
+	for i := range state {
+		state[i] = make([]cellState, width)
+	}
+
+	var solutions []grid.Grid
+	timedOut := false
+
+	pastDeadline := func() bool {
+		if deadline.IsZero() {
+			return false
+		}
+		if time.Now().After(deadline) {
+			timedOut = true
+			return true
+		}
+		return false
+	}
+
+	var propagate func(s [][]cellState) ([][]cellState, bool)
+	propagate = func(s [][]cellState) ([][]cellState, bool) {
+		for {
+			if pastDeadline() {
+				return nil, false
+			}
+			changed := false
+			for i := 0; i < height; i++ {
+				canFill, canEmpty, ok := lineCan(rowClues[i], s[i])
+				if !ok {
+					return nil, false
+				}
+				for j := 0; j < width; j++ {
+					if s[i][j] != unknown {
+						continue
+					}
+					if canFill[j] && !canEmpty[j] {
+						s[i][j] = filled
+						changed = true
+					} else if canEmpty[j] && !canFill[j] {
+						s[i][j] = empty
+						changed = true
+					} else if !canFill[j] && !canEmpty[j] {
+						return nil, false
+					}
+				}
+			}
+			for j := 0; j < width; j++ {
+				col := make([]cellState, height)
+				for i := 0; i < height; i++ {
+					col[i] = s[i][j]
+				}
+				canFill, canEmpty, ok := lineCan(colClues[j], col)
+				if !ok {
+					return nil, false
+				}
+				for i := 0; i < height; i++ {
+					if s[i][j] != unknown {
+						continue
+					}
+					if canFill[i] && !canEmpty[i] {
+						s[i][j] = filled
+						changed = true
+					} else if canEmpty[i] && !canFill[i] {
+						s[i][j] = empty
+						changed = true
+					} else if !canFill[i] && !canEmpty[i] {
+						return nil, false
+					}
+				}
+			}
+			if !changed {
+				trace.Printf("propagate: reached fixed point")
+				return s, true
+			}
+		}
+	}
+
+	var branch func(s [][]cellState) bool
+	branch = func(s [][]cellState) bool {
+		if pastDeadline() {
+			return true
+		}
+		s, ok := propagate(s)
+		if !ok {
+			if timedOut {
+				return true
+			}
+			trace.Printf("propagate: contradiction, backtracking")
+			return false
+		}
+
+		bi, bj := -1, -1
+		for i := 0; i < height && bi == -1; i++ {
+			for j := 0; j < width; j++ {
+				if s[i][j] == unknown {
+					bi, bj = i, j
+					break
+				}
+			}
+		}
+		if bi == -1 {
+			g := make(grid.Grid, height)
+			for i := 0; i < height; i++ {
+				g[i] = make([]rune, width)
+				for j := 0; j < width; j++ {
+					g[i][j] = stateRune(s[i][j])
+				}
+			}
+			solutions = append(solutions, g)
+			trace.Printf("branch: found solution #%d", len(solutions))
+			return limit > 0 && len(solutions) >= limit
+		}
+
+		trace.Printf("branch: guessing cell (%d,%d)", bi, bj)
+		for _, guess := range []cellState{filled, empty} {
+			next := cloneState(s)
+			next[bi][bj] = guess
+			if branch(next) {
+				return true
+			}
+		}
+		return false
+	}
+
+	branch(state)
+	if timedOut {
+		return nil, errTimedOut
+	}
+	return solutions, nil
+}
+
+func cloneState(s [][]cellState) [][]cellState {
+	out := make([][]cellState, len(s))
+	for i, row := range s {
+		out[i] = append([]cellState(nil), row...)
+	}
+	return out
+}
+
+func stateRune(s cellState) rune {
+	if s == filled {
+		return '1'
+	}
+	return '-'
+}
+
+// solvableByPropagation runs the same constraint propagation as solveGrid
+// but never branches. It returns ok == true only if propagation alone
+// determines every cell, which callers use to distinguish "trivial" puzzles
+// (no guessing required) from ones that need backtracking.
+func solvableByPropagation(rowClues, colClues [][]int, width, height int) (grid.Grid, bool, error) {
+	if len(rowClues) != height || len(colClues) != width {
+		return nil, false, errors.New("solvableByPropagation: clue dimensions do not match width/height")
+	}
+
+	state := make([][]cellState, height)
+	for i := range state {
+		state[i] = make([]cellState, width)
+	}
+
+	for {
+		changed := false
+		for i := 0; i < height; i++ {
+			canFill, canEmpty, ok := lineCan(rowClues[i], state[i])
+			if !ok {
+				return nil, false, nil
+			}
+			for j := 0; j < width; j++ {
+				if state[i][j] != unknown {
+					continue
+				}
+				if canFill[j] && !canEmpty[j] {
+					state[i][j] = filled
+					changed = true
+				} else if canEmpty[j] && !canFill[j] {
+					state[i][j] = empty
+					changed = true
+				} else if !canFill[j] && !canEmpty[j] {
+					return nil, false, nil
+				}
+			}
+		}
+		for j := 0; j < width; j++ {
+			col := make([]cellState, height)
+			for i := 0; i < height; i++ {
+				col[i] = state[i][j]
+			}
+			canFill, canEmpty, ok := lineCan(colClues[j], col)
+			if !ok {
+				return nil, false, nil
+			}
+			for i := 0; i < height; i++ {
+				if state[i][j] != unknown {
+					continue
+				}
+				if canFill[i] && !canEmpty[i] {
+					state[i][j] = filled
+					changed = true
+				} else if canEmpty[i] && !canFill[i] {
+					state[i][j] = empty
+					changed = true
+				} else if !canFill[i] && !canEmpty[i] {
+					return nil, false, nil
+				}
+			}
+		}
+		if !changed {
+			break
+		}
+	}
+
+	g := make(grid.Grid, height)
+	for i := 0; i < height; i++ {
+		g[i] = make([]rune, width)
+		for j := 0; j < width; j++ {
+			if state[i][j] == unknown {
+				return nil, false, nil
+			}
+			g[i][j] = stateRune(state[i][j])
+		}
+	}
+	return g, true, nil
+}
+
+// Solve reconstructs a grid from a single phase's row/column clues. It
+// returns ok == false if the clues admit no solution, and an error only if
+// the clues are malformed (e.g. mismatched dimensions).
+func Solve(rowClues, colClues [][]int, width, height int) (grid.Grid, bool, error) {
+	if len(rowClues) != height || len(colClues) != width {
+		return nil, false, errors.New("solve: clue dimensions do not match width/height")
+	}
+	solutions, err := solveGrid(rowClues, colClues, width, height, 1, time.Time{})
+	if err != nil {
+		return nil, false, err
+	}
+	if len(solutions) == 0 {
+		return nil, false, nil
+	}
+	return solutions[0], true, nil
+}
+
+// CountSolutions counts solutions for a single phase's clues, stopping as
+// soon as it finds two so callers can cheaply confirm uniqueness without
+// paying for full enumeration.
+func CountSolutions(rowClues, colClues [][]int, width, height int) (int, error) {
+	return countSolutionsDeadline(rowClues, colClues, width, height, time.Time{})
+}
+
+// countSolutionsDeadline is CountSolutions with a deadline threaded into the
+// underlying search, so a caller racing a timeout (e.g. IsUniquelySolvable)
+// can bound a single pathological grid's solve time instead of only
+// checking the deadline between calls.
+func countSolutionsDeadline(rowClues, colClues [][]int, width, height int, deadline time.Time) (int, error) {
+	if len(rowClues) != height || len(colClues) != width {
+		return 0, errors.New("countSolutions: clue dimensions do not match width/height")
+	}
+	solutions, err := solveGrid(rowClues, colClues, width, height, 2, deadline)
+	if err != nil {
+		return 0, err
+	}
+	return len(solutions), nil
+}
+
+// Difficulty constrains how a uniquely-solvable puzzle may be solved, on top
+// of the baseline requirement that it has exactly one solution.
+type Difficulty int
+
+const (
+	// AnyDifficulty accepts any uniquely-solvable puzzle.
+	AnyDifficulty Difficulty = iota
+	// RequireNonTrivial rejects puzzles solvable by propagation alone.
+	RequireNonTrivial
+	// RequireTrivial rejects puzzles that need backtracking.
+	RequireTrivial
+)
+
+// IsUniquelySolvable reports whether g yields a uniquely solvable Phase 1 and
+// Phase 2 puzzle, subject to want. deadline, if non-zero, bounds the
+// underlying solve of each phase so a single slow-to-solve candidate can't
+// run past it; it returns ErrTimedOut if deadline elapses mid-check.
+func IsUniquelySolvable(g grid.Grid, width, height int, want Difficulty, deadline time.Time) (bool, error) {
+	shadingRowClues, shadingColClues := grid.GenerateShadingClues(g)
+	erasingRowClues, erasingColClues := grid.GenerateErasingClues(g)
+
+	for _, phase := range []struct {
+		rowClues, colClues [][]int
+	}{
+		{shadingRowClues, shadingColClues},
+		{erasingRowClues, erasingColClues},
+	} {
+		count, err := countSolutionsDeadline(phase.rowClues, phase.colClues, width, height, deadline)
+		if err != nil {
+			return false, err
+		}
+		if count != 1 {
+			return false, nil
+		}
+		if want == AnyDifficulty {
+			continue
+		}
+		_, trivial, err := solvableByPropagation(phase.rowClues, phase.colClues, width, height)
+		if err != nil {
+			return false, err
+		}
+		if want == RequireNonTrivial && trivial {
+			return false, nil
+		}
+		if want == RequireTrivial && !trivial {
+			return false, nil
+		}
+	}
+	return true, nil
+}
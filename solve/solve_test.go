@@ -0,0 +1,100 @@
+package solve
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/joshspicer/nonogram/grid"
+)
+
+func TestLineCanRejectsForcedFillNoPlacementCovers(t *testing.T) {
+	// A single block of length 1 can cover at most one of these two cells,
+	// so a line where both are already known filled has no valid placement.
+	_, _, ok := lineCan([]int{1}, []cellState{filled, filled})
+	if ok {
+		t.Errorf("lineCan({1}, [filled,filled]) = ok, want not ok: no placement can cover both forced-filled cells")
+	}
+}
+
+func TestSolveUnique(t *testing.T) {
+	g := grid.Grid{
+		{'X', '1', '-'},
+		{'-', '-', '1'},
+		{'1', 'X', '1'},
+	}
+	rowClues, colClues := grid.GenerateShadingClues(g)
+
+	solved, ok, err := Solve(rowClues, colClues, 3, 3)
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if !ok {
+		t.Fatalf("Solve() = not solvable, want solvable")
+	}
+
+	gotRowClues, gotColClues := grid.GenerateShadingClues(solved)
+	if !reflect.DeepEqual(gotRowClues, rowClues) || !reflect.DeepEqual(gotColClues, colClues) {
+		t.Errorf("Solve() produced a grid whose clues = %v/%v, want %v/%v", gotRowClues, gotColClues, rowClues, colClues)
+	}
+}
+
+func TestCountSolutionsUnique(t *testing.T) {
+	g := grid.Grid{
+		{'X', '1', '-'},
+		{'-', '-', '1'},
+		{'1', 'X', '1'},
+	}
+	rowClues, colClues := grid.GenerateShadingClues(g)
+
+	count, err := CountSolutions(rowClues, colClues, 3, 3)
+	if err != nil {
+		t.Fatalf("CountSolutions() error = %v", err)
+	}
+	if count != 1 {
+		t.Errorf("CountSolutions() = %d, want 1", count)
+	}
+}
+
+func TestCountSolutionsAmbiguous(t *testing.T) {
+	// A 2x2 grid with a single "1" clue on every line admits two
+	// solutions: either diagonal filled.
+	rowClues := [][]int{{1}, {1}}
+	colClues := [][]int{{1}, {1}}
+
+	count, err := CountSolutions(rowClues, colClues, 2, 2)
+	if err != nil {
+		t.Fatalf("CountSolutions() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("CountSolutions() = %d, want 2", count)
+	}
+}
+
+func TestSolveGridRespectsDeadline(t *testing.T) {
+	// Ambiguous clues (each line admits many placements) that branch deeply
+	// enough to exercise the deadline check inside branch/propagate, not
+	// just between calls to solveGrid.
+	rowClues := [][]int{{1}, {1}, {1}, {1}}
+	colClues := [][]int{{1}, {1}, {1}, {1}}
+
+	_, err := solveGrid(rowClues, colClues, 4, 4, 0, time.Now().Add(-time.Second))
+	if err != errTimedOut {
+		t.Errorf("solveGrid() error = %v, want errTimedOut", err)
+	}
+}
+
+func TestSolveUnsolvable(t *testing.T) {
+	// A single row with one filled cell can't coexist with both columns
+	// being entirely empty.
+	rowClues := [][]int{{1}}
+	colClues := [][]int{{0}, {0}}
+
+	_, ok, err := Solve(rowClues, colClues, 2, 1)
+	if err != nil {
+		t.Fatalf("Solve() error = %v", err)
+	}
+	if ok {
+		t.Errorf("Solve() = solvable, want unsolvable")
+	}
+}
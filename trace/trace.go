@@ -0,0 +1,22 @@
+// Package trace provides the NONOGRAM_TRACE-gated diagnostic logger shared
+// by the solver and the CLI, so packages that don't otherwise depend on each
+// other can still log through the same switch.
+package trace
+
+import (
+	"fmt"
+	"os"
+)
+
+// Enabled mirrors tools like ARGH_TRACING: set NONOGRAM_TRACE=1 to have the
+// line-solver and reducer log their steps to stderr, which is useful when a
+// puzzle is stubbornly slow to solve or reduce.
+var Enabled = os.Getenv("NONOGRAM_TRACE") == "1"
+
+// Printf writes a diagnostic line to stderr when tracing is enabled.
+func Printf(format string, args ...interface{}) {
+	if !Enabled {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "[trace] "+format+"\n", args...)
+}